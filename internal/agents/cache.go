@@ -0,0 +1,42 @@
+package agents
+
+import (
+	"sync"
+	"time"
+)
+
+// ttlCache is a tiny in-memory cache with per-entry expiry, used to avoid
+// re-hitting external services for data that rarely changes.
+type ttlCache[T any] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry[T]
+}
+
+type ttlEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{ttl: ttl, entries: make(map[string]ttlEntry[T])}
+}
+
+func (c *ttlCache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		var zero T
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *ttlCache[T]) set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = ttlEntry[T]{value: value, expires: time.Now().Add(c.ttl)}
+}
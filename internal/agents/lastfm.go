@@ -0,0 +1,88 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// lastFMAgent implements ArtistInfoAgent and SimilarArtistsAgent against
+// the Last.fm REST API.
+type lastFMAgent struct {
+	apiKey string
+	client *http.Client
+}
+
+func newLastFM(apiKey string) *lastFMAgent {
+	return &lastFMAgent{apiKey: apiKey, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (a *lastFMAgent) ArtistInfo(ctx context.Context, artist string) (ArtistInfo, error) {
+	var out struct {
+		Artist struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+			Bio  struct {
+				Summary string `json:"summary"`
+			} `json:"bio"`
+		} `json:"artist"`
+	}
+	if err := a.call(ctx, "artist.getinfo", url.Values{"artist": {artist}}, &out); err != nil {
+		return ArtistInfo{}, err
+	}
+	if out.Artist.Name == "" {
+		return ArtistInfo{}, fmt.Errorf("lastfm: artist %q not found", artist)
+	}
+	return ArtistInfo{Name: out.Artist.Name, URL: out.Artist.URL, Bio: out.Artist.Bio.Summary}, nil
+}
+
+func (a *lastFMAgent) SimilarArtists(ctx context.Context, artist string) ([]SimilarArtist, error) {
+	var out struct {
+		SimilarArtists struct {
+			Artist []struct {
+				Name  string `json:"name"`
+				Match string `json:"match"`
+			} `json:"artist"`
+		} `json:"similarartists"`
+	}
+	if err := a.call(ctx, "artist.getsimilar", url.Values{"artist": {artist}}, &out); err != nil {
+		return nil, err
+	}
+
+	sims := make([]SimilarArtist, 0, len(out.SimilarArtists.Artist))
+	for _, s := range out.SimilarArtists.Artist {
+		match, _ := strconv.ParseFloat(s.Match, 64)
+		sims = append(sims, SimilarArtist{Name: s.Name, Match: match})
+	}
+	return sims, nil
+}
+
+func (a *lastFMAgent) call(ctx context.Context, method string, params url.Values, out any) error {
+	params.Set("method", method)
+	params.Set("api_key", a.apiKey)
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://ws.audioscrobbler.com/2.0/?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("lastfm: build request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("lastfm: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("lastfm: unexpected status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("lastfm: decode response: %w", err)
+	}
+	return nil
+}
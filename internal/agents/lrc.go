@@ -0,0 +1,47 @@
+package agents
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var lrcTimestamp = regexp.MustCompile(`^\[(\d+):(\d+)(?:\.(\d+))?\](.*)$`)
+
+// parseLRC parses LRC-format synced lyrics ("[mm:ss.xx]text" per line)
+// into Lyrics. Text with no recognizable timestamps is kept as plain
+// lyrics instead.
+func parseLRC(text string) Lyrics {
+	var lines []LyricsLine
+	for _, raw := range strings.Split(text, "\n") {
+		m := lrcTimestamp.FindStringSubmatch(strings.TrimRight(raw, "\r"))
+		if m == nil {
+			continue
+		}
+
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		ms := 0
+		if frac := m[3]; frac != "" {
+			n, _ := strconv.Atoi(frac)
+			switch len(frac) {
+			case 1:
+				ms = n * 100
+			case 2:
+				ms = n * 10
+			default:
+				ms = n
+			}
+		}
+
+		lines = append(lines, LyricsLine{
+			TimestampMS: int64(minutes)*60000 + int64(seconds)*1000 + int64(ms),
+			Text:        strings.TrimSpace(m[4]),
+		})
+	}
+
+	if len(lines) == 0 {
+		return Lyrics{Plain: text}
+	}
+	return Lyrics{Synced: true, Lines: lines}
+}
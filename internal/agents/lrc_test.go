@@ -0,0 +1,37 @@
+package agents
+
+import "testing"
+
+func TestParseLRC(t *testing.T) {
+	const text = "[00:01.00]First line\n[00:02.50]Second line\n[01:00]Third line\n"
+
+	got := parseLRC(text)
+	if !got.Synced {
+		t.Fatal("parseLRC() Synced = false, want true for timestamped input")
+	}
+	want := []LyricsLine{
+		{TimestampMS: 1000, Text: "First line"},
+		{TimestampMS: 2500, Text: "Second line"},
+		{TimestampMS: 60000, Text: "Third line"},
+	}
+	if len(got.Lines) != len(want) {
+		t.Fatalf("parseLRC() returned %d lines, want %d", len(got.Lines), len(want))
+	}
+	for i, line := range want {
+		if got.Lines[i] != line {
+			t.Fatalf("parseLRC() line %d = %+v, want %+v", i, got.Lines[i], line)
+		}
+	}
+}
+
+func TestParseLRCPlainFallback(t *testing.T) {
+	const text = "Just some plain lyrics\nwith no timestamps at all"
+
+	got := parseLRC(text)
+	if got.Synced {
+		t.Fatal("parseLRC() Synced = true for untimestamped input, want false")
+	}
+	if got.Plain != text {
+		t.Fatalf("parseLRC() Plain = %q, want %q", got.Plain, text)
+	}
+}
@@ -0,0 +1,59 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// lrclibAgent implements LyricsAgent against the LRCLIB API, which serves
+// both synced (LRC) and plain lyrics keyed by artist/track/duration.
+type lrclibAgent struct {
+	client *http.Client
+}
+
+func newLRCLIB() *lrclibAgent {
+	return &lrclibAgent{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (a *lrclibAgent) Lyrics(ctx context.Context, artist, title string, durationSec int) (Lyrics, error) {
+	q := url.Values{"artist_name": {artist}, "track_name": {title}}
+	if durationSec > 0 {
+		q.Set("duration", strconv.Itoa(durationSec))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://lrclib.net/api/get?"+q.Encode(), nil)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("lrclib: build request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("lrclib: request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Lyrics{}, fmt.Errorf("lrclib: unexpected status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		SyncedLyrics string `json:"syncedLyrics"`
+		PlainLyrics  string `json:"plainLyrics"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Lyrics{}, fmt.Errorf("lrclib: decode response: %w", err)
+	}
+
+	switch {
+	case out.SyncedLyrics != "":
+		return parseLRC(out.SyncedLyrics), nil
+	case out.PlainLyrics != "":
+		return Lyrics{Plain: out.PlainLyrics}, nil
+	default:
+		return Lyrics{}, fmt.Errorf("lrclib: no lyrics for %q - %q", artist, title)
+	}
+}
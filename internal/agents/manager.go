@@ -0,0 +1,138 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config selects which agents Manager uses, in what order, and how they
+// authenticate.
+type Config struct {
+	// Order lists agent names to try, in priority order, e.g.
+	// []string{"lastfm", "spotify", "lrclib"}. Unknown names, and agents
+	// missing required credentials, are skipped.
+	Order []string
+
+	LastFMAPIKey    string
+	SpotifyKey      string
+	SpotifySecretID string
+
+	// CacheTTL controls how long results are cached per agent capability.
+	CacheTTL time.Duration
+}
+
+// ParseOrder splits a comma-separated agent order string (as read from
+// config/env, e.g. "lastfm,spotify,lrclib") into agent names.
+func ParseOrder(s string) []string {
+	var order []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// Manager fans enrichment requests out to a configured, ordered chain of
+// agents per capability, caching successful results.
+type Manager struct {
+	artistInfo     []ArtistInfoAgent
+	similarArtists []SimilarArtistsAgent
+	lyrics         []LyricsAgent
+
+	artistInfoCache     *ttlCache[ArtistInfo]
+	similarArtistsCache *ttlCache[[]SimilarArtist]
+	lyricsCache         *ttlCache[Lyrics]
+}
+
+// New builds a Manager from cfg, wiring up only the agents whose
+// credentials are present.
+func New(cfg Config) *Manager {
+	ttl := cfg.CacheTTL
+	if ttl <= 0 {
+		ttl = 6 * time.Hour
+	}
+
+	m := &Manager{
+		artistInfoCache:     newTTLCache[ArtistInfo](ttl),
+		similarArtistsCache: newTTLCache[[]SimilarArtist](ttl),
+		lyricsCache:         newTTLCache[Lyrics](ttl),
+	}
+
+	for _, name := range cfg.Order {
+		switch name {
+		case "lastfm":
+			if cfg.LastFMAPIKey == "" {
+				continue
+			}
+			lfm := newLastFM(cfg.LastFMAPIKey)
+			m.artistInfo = append(m.artistInfo, lfm)
+			m.similarArtists = append(m.similarArtists, lfm)
+
+		case "spotify":
+			if cfg.SpotifyKey == "" || cfg.SpotifySecretID == "" {
+				continue
+			}
+			m.artistInfo = append(m.artistInfo, newSpotify(cfg.SpotifyKey, cfg.SpotifySecretID))
+
+		case "lrclib":
+			m.lyrics = append(m.lyrics, newLRCLIB())
+		}
+	}
+
+	return m
+}
+
+// ArtistInfo resolves biographical info for artist, trying each configured
+// agent in order.
+func (m *Manager) ArtistInfo(ctx context.Context, artist string) (ArtistInfo, error) {
+	if v, ok := m.artistInfoCache.get(artist); ok {
+		return v, nil
+	}
+	for _, a := range m.artistInfo {
+		if info, err := a.ArtistInfo(ctx, artist); err == nil {
+			m.artistInfoCache.set(artist, info)
+			return info, nil
+		}
+	}
+	return ArtistInfo{}, fmt.Errorf("agents: no artist info available for %q", artist)
+}
+
+// SimilarArtists resolves similar-artist recommendations for artist.
+func (m *Manager) SimilarArtists(ctx context.Context, artist string) ([]SimilarArtist, error) {
+	if v, ok := m.similarArtistsCache.get(artist); ok {
+		return v, nil
+	}
+	for _, a := range m.similarArtists {
+		if sim, err := a.SimilarArtists(ctx, artist); err == nil {
+			m.similarArtistsCache.set(artist, sim)
+			return sim, nil
+		}
+	}
+	return nil, fmt.Errorf("agents: no similar artists available for %q", artist)
+}
+
+// Lyrics resolves lyrics for a track, trying configured agents and then
+// falling back to a .lrc sidecar file next to trackPath.
+func (m *Manager) Lyrics(ctx context.Context, artist, title, trackPath string, durationSec int) (Lyrics, error) {
+	key := artist + "\x00" + title
+	if v, ok := m.lyricsCache.get(key); ok {
+		return v, nil
+	}
+
+	for _, a := range m.lyrics {
+		if lyr, err := a.Lyrics(ctx, artist, title, durationSec); err == nil {
+			m.lyricsCache.set(key, lyr)
+			return lyr, nil
+		}
+	}
+
+	if lyr, err := lyricsFromSidecar(trackPath); err == nil {
+		m.lyricsCache.set(key, lyr)
+		return lyr, nil
+	}
+
+	return Lyrics{}, fmt.Errorf("agents: no lyrics available for %q - %q", artist, title)
+}
@@ -0,0 +1,23 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lyricsFromSidecar looks for a .lrc file next to trackPath, sharing its
+// base name, as a last-resort local fallback when no agent has lyrics.
+func lyricsFromSidecar(trackPath string) (Lyrics, error) {
+	if trackPath == "" {
+		return Lyrics{}, fmt.Errorf("agents: no track path to look for a sidecar")
+	}
+
+	lrcPath := strings.TrimSuffix(trackPath, filepath.Ext(trackPath)) + ".lrc"
+	data, err := os.ReadFile(lrcPath)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("agents: no sidecar lyrics at %s: %w", lrcPath, err)
+	}
+	return parseLRC(string(data)), nil
+}
@@ -0,0 +1,144 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spotifyAgent implements ArtistInfoAgent against the Spotify Web API,
+// authenticating via the client-credentials OAuth flow.
+type spotifyAgent struct {
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func newSpotify(clientID, clientSecret string) *spotifyAgent {
+	return &spotifyAgent{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// token returns a cached access token, refreshing it once it's expired.
+func (a *spotifyAgent) token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.accessToken != "" && time.Now().Before(a.expiresAt) {
+		return a.accessToken, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		"https://accounts.spotify.com/api/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("spotify: build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(a.clientID, a.clientSecret)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("spotify: token request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("spotify: token request status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("spotify: decode token: %w", err)
+	}
+
+	a.accessToken = out.AccessToken
+	a.expiresAt = time.Now().Add(time.Duration(out.ExpiresIn) * time.Second)
+	return a.accessToken, nil
+}
+
+func (a *spotifyAgent) ArtistInfo(ctx context.Context, artist string) (ArtistInfo, error) {
+	token, err := a.token(ctx)
+	if err != nil {
+		return ArtistInfo{}, err
+	}
+
+	id, name, err := a.searchArtist(ctx, token, artist)
+	if err != nil {
+		return ArtistInfo{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.spotify.com/v1/artists/"+id, nil)
+	if err != nil {
+		return ArtistInfo{}, fmt.Errorf("spotify: build artist request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return ArtistInfo{}, fmt.Errorf("spotify: artist request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ArtistInfo{}, fmt.Errorf("spotify: artist request status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		ExternalURLs struct {
+			Spotify string `json:"spotify"`
+		} `json:"external_urls"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return ArtistInfo{}, fmt.Errorf("spotify: decode artist: %w", err)
+	}
+
+	return ArtistInfo{Name: name, URL: out.ExternalURLs.Spotify}, nil
+}
+
+func (a *spotifyAgent) searchArtist(ctx context.Context, token, artist string) (id, name string, err error) {
+	q := url.Values{"q": {artist}, "type": {"artist"}, "limit": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.spotify.com/v1/search?"+q.Encode(), nil)
+	if err != nil {
+		return "", "", fmt.Errorf("spotify: build search request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("spotify: search request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("spotify: search request status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Artists struct {
+			Items []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"items"`
+		} `json:"artists"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", "", fmt.Errorf("spotify: decode search: %w", err)
+	}
+	if len(out.Artists.Items) == 0 {
+		return "", "", fmt.Errorf("spotify: artist %q not found", artist)
+	}
+	return out.Artists.Items[0].ID, out.Artists.Items[0].Name, nil
+}
@@ -0,0 +1,60 @@
+// Package agents enriches tracks/albums/artists with data from external
+// services (Last.fm, Spotify, LRCLIB) through a small, pluggable
+// agent-chain: each capability tries its configured agents in order and
+// returns the first successful result.
+package agents
+
+import "context"
+
+// ArtistInfo is biographical/reference data about an artist.
+type ArtistInfo struct {
+	Name string `json:"name"`
+	Bio  string `json:"bio,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// AlbumInfo is descriptive data about an album.
+type AlbumInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// SimilarArtist is one entry in a similar-artists recommendation.
+type SimilarArtist struct {
+	Name  string  `json:"name"`
+	Match float64 `json:"match"`
+}
+
+// LyricsLine is a single timed line of synced lyrics.
+type LyricsLine struct {
+	TimestampMS int64  `json:"timestampMs"`
+	Text        string `json:"text"`
+}
+
+// Lyrics holds either synced (line-timed) or plain lyrics for a track.
+type Lyrics struct {
+	Synced bool         `json:"synced"`
+	Lines  []LyricsLine `json:"lines,omitempty"`
+	Plain  string       `json:"plain,omitempty"`
+}
+
+// ArtistInfoAgent resolves biographical data for an artist by name.
+type ArtistInfoAgent interface {
+	ArtistInfo(ctx context.Context, artist string) (ArtistInfo, error)
+}
+
+// AlbumInfoAgent resolves descriptive data for an album by artist+name.
+type AlbumInfoAgent interface {
+	AlbumInfo(ctx context.Context, artist, album string) (AlbumInfo, error)
+}
+
+// SimilarArtistsAgent recommends artists similar to a given one.
+type SimilarArtistsAgent interface {
+	SimilarArtists(ctx context.Context, artist string) ([]SimilarArtist, error)
+}
+
+// LyricsAgent resolves lyrics for a track by artist/title (and optionally
+// duration, which some providers use to disambiguate).
+type LyricsAgent interface {
+	Lyrics(ctx context.Context, artist, title string, durationSec int) (Lyrics, error)
+}
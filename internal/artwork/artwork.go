@@ -0,0 +1,132 @@
+// Package artwork serves track cover art, decoupled from the track JSON
+// payload: callers fetch it from /artwork/{id}?size=NNN instead of having
+// it inlined as a data URL.
+package artwork
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+
+	"yhsrv/internal/diskcache"
+	"yhsrv/internal/persistence"
+
+	"github.com/disintegration/imaging"
+	"github.com/dhowden/tag"
+)
+
+// Service resolves and serves artwork for indexed tracks.
+type Service struct {
+	library *persistence.Store
+	cache   *diskcache.Cache
+	quality int
+}
+
+// New returns a Service backed by library, caching resized output under
+// cacheDir (capped at maxBytes) and re-encoding JPEG at quality (1-100).
+func New(library *persistence.Store, cacheDir string, maxBytes int64, quality int) (*Service, error) {
+	c, err := newCache(cacheDir, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if quality <= 0 {
+		quality = 85
+	}
+	return &Service{library: library, cache: c, quality: quality}, nil
+}
+
+// Get returns a reader over the artwork for track id, resized so its
+// longest side is size pixels (size <= 0 means "original resolution"),
+// re-encoded as JPEG. If no source art can be resolved, a placeholder
+// image is served instead. The caller must Close the returned reader.
+func (s *Service) Get(id string, size int) (io.ReadCloser, error) {
+	raw, mtime, cacheID := s.resolve(id)
+
+	key := cacheKey(cacheID, size, mtime, s.quality)
+	if path, ok := s.cache.Lookup(key); ok {
+		return os.Open(path)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("artwork: decode source: %w", err)
+	}
+	if size > 0 {
+		img = imaging.Resize(img, size, 0, imaging.Lanczos)
+	}
+
+	path := s.cache.Path(key)
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("artwork: create cache file: %w", err)
+	}
+	if err := jpeg.Encode(f, img, &jpeg.Options{Quality: s.quality}); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("artwork: encode jpeg: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("artwork: stat cache file: %w", err)
+	}
+	f.Close()
+	s.cache.Commit(key, info.Size())
+
+	return os.Open(path)
+}
+
+// resolve returns the raw source image bytes for id, its mtime (for cache
+// invalidation), and the key to cache it under. It falls back to the
+// embedded placeholder whenever the track or its art can't be found.
+func (s *Service) resolve(id string) (raw []byte, mtime int64, cacheID string) {
+	track, err := s.library.GetTrackByID(id)
+	if err == nil {
+		if raw, mtime, err := resolveSource(track.Path); err == nil {
+			return raw, mtime, id
+		}
+	}
+
+	raw, err = resourcesFS.ReadFile(placeholderPath)
+	if err != nil {
+		// The placeholder is embedded at build time; this can't happen.
+		panic(fmt.Sprintf("artwork: embedded placeholder missing: %v", err))
+	}
+	return raw, 0, "placeholder"
+}
+
+// resolveSource finds the raw image bytes for a track: its embedded tag
+// picture if present, else cover.jpg/png next to it, along with the mtime
+// of whichever source it used (for cache invalidation).
+func resolveSource(trackPath string) ([]byte, int64, error) {
+	if f, err := os.Open(trackPath); err == nil {
+		defer f.Close()
+		if md, err := tag.ReadFrom(f); err == nil {
+			if pic := md.Picture(); pic != nil && len(pic.Data) > 0 {
+				if info, err := f.Stat(); err == nil {
+					return pic.Data, info.ModTime().Unix(), nil
+				}
+			}
+		}
+	}
+
+	dir := filepath.Dir(trackPath)
+	for _, name := range []string{"cover.jpg", "cover.jpeg", "cover.png"} {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return data, info.ModTime().Unix(), nil
+	}
+
+	return nil, 0, fmt.Errorf("artwork: no source found for %s", trackPath)
+}
@@ -0,0 +1,23 @@
+package artwork
+
+import (
+	"fmt"
+
+	"yhsrv/internal/diskcache"
+)
+
+// newCache returns a disk cache rooted at dir, capped at maxBytes.
+func newCache(dir string, maxBytes int64) (*diskcache.Cache, error) {
+	c, err := diskcache.New(dir, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("artwork: %w", err)
+	}
+	return c, nil
+}
+
+// cacheKey builds the cache key for a given artwork request: trackID,
+// requested size, source mtime, and quality, so that changing any of
+// those naturally invalidates stale entries.
+func cacheKey(trackID string, size int, mtime int64, quality int) string {
+	return fmt.Sprintf("%s.%d.%d.%d.jpg", trackID, size, mtime, quality)
+}
@@ -0,0 +1,8 @@
+package artwork
+
+import "embed"
+
+//go:embed resources/placeholder.png
+var resourcesFS embed.FS
+
+const placeholderPath = "resources/placeholder.png"
@@ -0,0 +1,109 @@
+// Package diskcache implements a disk-backed LRU of opaque byte blobs,
+// shared by the streaming and artwork packages, which otherwise each kept
+// their own near-identical copy of the same eviction bookkeeping.
+package diskcache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache is a disk-backed LRU keyed by an arbitrary string, evicting
+// least-recently-used entries once the total size exceeds maxBytes.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	order []string // least-recently-used first
+	sizes map[string]int64
+	total int64
+}
+
+// New creates a cache rooted at dir, creating it if necessary, and
+// indexes whatever entries are already on disk. maxBytes <= 0 disables
+// eviction.
+func New(dir string, maxBytes int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("diskcache: create cache dir: %w", err)
+	}
+	c := &Cache{dir: dir, maxBytes: maxBytes, sizes: make(map[string]int64)}
+	c.loadExisting()
+	return c, nil
+}
+
+func (c *Cache) loadExisting() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		key := e.Name()
+		c.order = append(c.order, key)
+		c.sizes[key] = info.Size()
+		c.total += info.Size()
+	}
+}
+
+// Path returns the on-disk path for a cache key, without checking existence.
+func (c *Cache) Path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// Lookup returns the cache path and true if key is already cached on disk.
+func (c *Cache) Lookup(key string) (string, bool) {
+	path := c.Path(key)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	c.touch(key)
+	return path, true
+}
+
+// Commit registers a freshly written cache file of the given size under
+// key, evicting older entries if the cache is now over budget.
+func (c *Cache) Commit(key string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.sizes[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	c.sizes[key] = size
+	c.total += size
+	c.evictLocked()
+}
+
+func (c *Cache) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, key)
+}
+
+func (c *Cache) evictLocked() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	for c.total > c.maxBytes && len(c.order) > 0 {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		c.total -= c.sizes[oldest]
+		delete(c.sizes, oldest)
+		os.Remove(c.Path(oldest))
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"yhsrv/internal/agents"
+
+	"go.oneofone.dev/gserv"
+)
+
+var agentManager = agents.New(agents.Config{
+	Order:           agents.ParseOrder(envOr("AGENTS", "lastfm,spotify,lrclib")),
+	LastFMAPIKey:    os.Getenv("LASTFM_API_KEY"),
+	SpotifyKey:      os.Getenv("SPOTIFY_API_KEY"),
+	SpotifySecretID: os.Getenv("SPOTIFY_SECRET_ID"),
+	CacheTTL:        6 * time.Hour,
+})
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// Agents returns the shared metadata agent manager, for wiring up other
+// REST surfaces (e.g. the Subsonic layer) that need enrichment data.
+func Agents() *agents.Manager {
+	return agentManager
+}
+
+// GetArtistInfo handles GET /artist/{id}/info
+func GetArtistInfo(ctx *gserv.Context) gserv.Response {
+	artist, err := library.GetArtist(ctx.Param("id"))
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Artist not found")
+	}
+
+	info, err := agentManager.ArtistInfo(ctx.Req.Context(), artist.Name)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "No artist info available")
+	}
+	return gserv.NewJSONResponse(info)
+}
+
+// GetSimilarArtists handles GET /artist/{id}/similar
+func GetSimilarArtists(ctx *gserv.Context) gserv.Response {
+	artist, err := library.GetArtist(ctx.Param("id"))
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Artist not found")
+	}
+
+	similar, err := agentManager.SimilarArtists(ctx.Req.Context(), artist.Name)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "No similar artists available")
+	}
+	return gserv.NewJSONResponse(similar)
+}
+
+// GetLyrics handles GET /track/{id}/lyrics
+func GetLyrics(ctx *gserv.Context) gserv.Response {
+	track, err := library.GetTrackByID(ctx.Param("id"))
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
+	}
+
+	lyrics, err := agentManager.Lyrics(ctx.Req.Context(), track.Artist, track.Title, track.Path, 0)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "No lyrics available")
+	}
+	return gserv.NewJSONResponse(lyrics)
+}
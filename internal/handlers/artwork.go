@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"yhsrv/internal/artwork"
+
+	"go.oneofone.dev/gserv"
+)
+
+var artService = mustOpenArtwork()
+
+func mustOpenArtwork() *artwork.Service {
+	svc, err := artwork.New(library, "./cache/artwork", 512<<20, 85) // 512MiB
+	if err != nil {
+		log.Fatalf("failed to open artwork cache: %v", err)
+	}
+	return svc
+}
+
+// Artwork returns the shared artwork service, for wiring up other REST
+// surfaces (e.g. the Subsonic layer) that serve cover art.
+func Artwork() *artwork.Service {
+	return artService
+}
+
+// GetArtwork handles GET /artwork/{id}?size=NNN
+func GetArtwork(ctx *gserv.Context) gserv.Response {
+	id := ctx.Param("id")
+	size, _ := strconv.Atoi(ctx.Req.URL.Query().Get("size"))
+
+	rc, err := artService.Get(id, size)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Artwork not found")
+	}
+	defer rc.Close()
+
+	ctx.Header().Set("Content-Type", "image/jpeg")
+	ctx.Header().Set("Cache-Control", "public, max-age=604800")
+	ctx.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(ctx, rc); err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "failed to write artwork")
+	}
+	return nil
+}
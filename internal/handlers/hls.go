@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"yhsrv/internal/streaming"
+
+	"go.oneofone.dev/gserv"
+)
+
+var hlsService = streaming.NewHLS("./cache/hls")
+
+// GetHLSMaster handles GET /hls/{id}/master.m3u8
+func GetHLSMaster(ctx *gserv.Context) gserv.Response {
+	id := ctx.Param("id")
+	if _, err := library.GetTrackByID(id); err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
+	}
+
+	ctx.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+	ctx.WriteHeader(http.StatusOK)
+	ctx.Write(hlsService.MasterPlaylist(id, streaming.DefaultVariants))
+	return nil
+}
+
+// GetHLSResource handles GET /hls/{id}/{variant}/{file}, where file is
+// either the variant's "index.m3u8" playlist or one of its "seg-N.ts"
+// segments. Segments are transcoded on first request and cached on disk.
+func GetHLSResource(ctx *gserv.Context) gserv.Response {
+	id, variant, file := ctx.Param("id"), ctx.Param("variant"), ctx.Param("file")
+
+	track, err := library.GetTrackByID(id)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
+	}
+
+	if file == "index.m3u8" {
+		playlist, err := hlsService.VariantPlaylist(ctx.Req.Context(), id, variant, track.Path, streaming.DefaultVariants)
+		if err != nil {
+			return gserv.NewJSONErrorResponse(http.StatusNotFound, "Unknown HLS variant")
+		}
+		ctx.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+		ctx.WriteHeader(http.StatusOK)
+		ctx.Write(playlist)
+		return nil
+	}
+
+	n, ok := parseSegmentIndex(file)
+	if !ok {
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Unknown HLS resource")
+	}
+
+	segPath, err := hlsService.Segment(ctx.Req.Context(), id, variant, n, track.Path, streaming.DefaultVariants)
+	if err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "failed to transcode segment")
+	}
+	if err := streaming.ServeFile(ctx, ctx.Req, segPath, "video/mp2t"); err != nil {
+		return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "failed to serve segment")
+	}
+	return nil
+}
+
+func parseSegmentIndex(file string) (int, bool) {
+	if !strings.HasPrefix(file, "seg-") || !strings.HasSuffix(file, ".ts") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(file, "seg-"), ".ts"))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -1,68 +1,96 @@
 package handlers
 
 import (
-	"fmt"
-	"io"
+	"log"
 	"net/http"
-	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"yhsrv/internal/streaming"
+
+	"go.oneofone.dev/gserv"
 )
 
-// StreamMusic handles serving music files for streaming
-func StreamMusic(w http.ResponseWriter, r *http.Request) {
-	// Extract the track ID (file path) from the URL
-	trackID := strings.TrimPrefix(r.URL.Path, "/tracks/")
-	filePath := filepath.Join("path/to/music/directory", trackID) // Modify with your actual directory
+// transcodeCache and transcoder back every transcoding streaming request.
+// They're process-wide singletons since they own a shared disk cache
+// directory and LRU state.
+var (
+	transcodeCache = mustOpenTranscodeCache("./cache/transcode", 2<<30) // 2GiB
+	transcoder     = streaming.NewTranscoder(transcodeCache)
+)
 
-	file, err := os.Open(filePath)
+func mustOpenTranscodeCache(dir string, maxBytes int64) *streaming.TranscodeCache {
+	cache, err := streaming.NewTranscodeCache(dir, maxBytes)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("could not open file: %v", err), http.StatusNotFound)
-		return
+		log.Fatalf("failed to open transcode cache: %v", err)
 	}
-	defer file.Close()
+	return cache
+}
+
+var contentTypeByExt = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".flac": "audio/flac",
+	".wav":  "audio/wav",
+}
 
-	// Get file size and set the Content-Type based on file extension
-	stat, err := file.Stat()
+// StreamTrack handles GET /stream/{id}. Without ?format=, it serves the
+// source file directly with full Range support (including multi-range).
+// With ?format=X&bitrate=N, it transcodes on the fly via ffmpeg: the first
+// request for a given (id, format, bitrate) streams chunked while also
+// populating the disk cache, and subsequent requests (including seeks)
+// are served straight from the cached file with Range support.
+func StreamTrack(ctx *gserv.Context) gserv.Response {
+	id := ctx.Param("id")
+	track, err := library.GetTrackByID(id)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("could not get file stats: %v", err), http.StatusInternalServerError)
-		return
+		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
 	}
-	fileSize := stat.Size()
 
-	ext := strings.ToLower(filepath.Ext(filePath))
-	var contentType string
-	switch ext {
-	case ".mp3":
-		contentType = "audio/mpeg"
-	case ".m4a":
-		contentType = "audio/mp4"
-	case ".aac":
-		contentType = "audio/aac"
-	case ".ogg":
-		contentType = "audio/ogg"
-	case ".flac":
-		contentType = "audio/flac"
-	case ".wav":
-		contentType = "audio/wav"
-	default:
-		contentType = "application/octet-stream"
+	srcPath := track.Path
+	format := strings.ToLower(ctx.Req.URL.Query().Get("format"))
+	if format == "" {
+		contentType := contentTypeByExt[strings.ToLower(filepath.Ext(srcPath))]
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		if err := streaming.ServeFile(ctx, ctx.Req, srcPath, contentType); err != nil {
+			return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "failed to stream track")
+		}
+		return nil
 	}
 
-	// Handle Range Request (for efficient streaming)
-	rangeHeader := r.Header.Get("Range")
-	if rangeHeader != "" {
-		// Parse the range header and set the appropriate response
-		// (this is a simplified version; in a real-world app, you should handle byte ranges)
-		http.ServeContent(w, r, filePath, stat.ModTime(), file)
-		return
+	if !streaming.ValidFormat(format) {
+		return gserv.NewJSONErrorResponse(http.StatusBadRequest, "unsupported format")
 	}
 
-	// No range request, serve the entire file
-	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", fileSize))
-	_, err = io.Copy(w, file)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("error while streaming file: %v", err), http.StatusInternalServerError)
+	bitrate, _ := strconv.Atoi(ctx.Req.URL.Query().Get("bitrate"))
+	if bitrate <= 0 {
+		bitrate = 192
+	}
+
+	key := streaming.Key(id, format, bitrate)
+	if cachedPath, ok := transcodeCache.Lookup(key); ok {
+		if err := streaming.ServeFile(ctx, ctx.Req, cachedPath, streaming.ContentType(format)); err != nil {
+			return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "failed to stream transcoded track")
+		}
+		return nil
+	}
+
+	// Not cached yet: transcode length is unknown up front, so fall back to
+	// chunked transfer rather than honoring Range on this first pass.
+	ctx.Header().Set("Content-Type", streaming.ContentType(format))
+	ctx.Header().Set("Accept-Ranges", "bytes")
+	ctx.WriteHeader(http.StatusOK)
+	if err := transcoder.Stream(ctx.Req.Context(), ctx, srcPath, key, format, bitrate); err != nil {
+		// Headers (and possibly a partial body) are already written, so a
+		// JSON error response here would just get appended to the audio
+		// stream. The client already sees a broken response; all we can
+		// do now is log it.
+		log.Printf("stream %s: transcode failed: %v", id, err)
 	}
+	return nil
 }
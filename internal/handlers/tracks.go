@@ -1,17 +1,34 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
-	"path/filepath"
 
-	"yhsrv/internal/utils"
+	"yhsrv/internal/persistence"
 
 	"go.oneofone.dev/gserv"
 )
 
+// library is the process-wide handle to the SQLite track index, kept open
+// for the lifetime of the server. main wires a scanner to keep it current.
+var library = mustOpenLibrary("./data/library.db")
+
+func mustOpenLibrary(path string) *persistence.Store {
+	store, err := persistence.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open library store: %v", err)
+	}
+	return store
+}
+
+// Library returns the shared library index, for wiring up a scanner.
+func Library() *persistence.Store {
+	return library
+}
+
 // GetTracks handles GET /tracks
 func GetTracks(ctx *gserv.Context) gserv.Response {
-	tracks, err := utils.GetTracksFromDirectory("./music")
+	tracks, err := library.ListTracks()
 	if err != nil {
 		return gserv.NewJSONErrorResponse(http.StatusInternalServerError, "Failed to fetch tracks")
 	}
@@ -21,26 +38,13 @@ func GetTracks(ctx *gserv.Context) gserv.Response {
 // GetTrack handles GET /track/{id}
 func GetTrack(ctx *gserv.Context) gserv.Response {
 	id := ctx.Param("id")
-	track, err := utils.GetTrackByID(id)
+	track, err := library.GetTrackByID(id)
 	if err != nil {
 		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
 	}
 	return gserv.NewJSONResponse(track)
 }
 
-// StreamTrack handles GET /stream/{id}
-func StreamTrack(ctx *gserv.Context) gserv.Response {
-	id := ctx.Param("id")
-	track, err := utils.GetTrackByID(id)
-	if err != nil {
-		return gserv.NewJSONErrorResponse(http.StatusNotFound, "Track not found")
-	}
-
-	// Serve the audio file dynamically
-	filePath := filepath.Join("./music", track.Album, track.Name)
-	return gserv.NewJSONResponse(filePath)
-}
-
 // Ping for health check
 func Ping(ctx *gserv.Context) gserv.Response {
 	return gserv.NewJSONResponse(map[string]string{"message": "pong"})
@@ -0,0 +1,8 @@
+package models
+
+// Album groups tracks that share the same album tag under one artist.
+type Album struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ArtistID string `json:"artistId,omitempty"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// Artist represents a distinct performing artist derived from track tags.
+type Artist struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
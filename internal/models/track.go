@@ -2,11 +2,13 @@ package models
 
 // Track represents an individual track with metadata
 type Track struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Artist  string `json:"artist,omitempty"`
-	Album   string `json:"album,omitempty"`
-	Path    string `json:"path"`
-	Format  string `json:"format"`
-	Artwork string `json:"artwork,omitempty"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Artist    string `json:"artist,omitempty"`
+	ArtistID  string `json:"artistId,omitempty"`
+	Album     string `json:"album,omitempty"`
+	AlbumID   string `json:"albumId,omitempty"`
+	Path      string `json:"path"`
+	Format    string `json:"format"`
+	ArtworkID string `json:"artworkId,omitempty"`
 }
@@ -0,0 +1,15 @@
+package persistence
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+// HashID derives a short, stable, deterministic ID from a set of parts
+// (e.g. a kind tag plus a file path). Unlike using the path itself as the
+// ID, this keeps IDs opaque and fixed-width.
+func HashID(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,334 @@
+// Package persistence provides the SQLite-backed library index: tracks,
+// albums, artists, and the bookkeeping (mtime/size) the scanner needs to
+// tell whether a file needs re-reading.
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+
+	"yhsrv/internal/models"
+
+	_ "modernc.org/sqlite"
+)
+
+// Store is a handle to the library's SQLite index. It is safe for
+// concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS artists (
+	id   TEXT PRIMARY KEY,
+	name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS albums (
+	id        TEXT PRIMARY KEY,
+	name      TEXT NOT NULL,
+	artist_id TEXT REFERENCES artists(id)
+);
+
+CREATE TABLE IF NOT EXISTS tracks (
+	id        TEXT PRIMARY KEY,
+	path      TEXT NOT NULL UNIQUE,
+	title     TEXT,
+	artist_id TEXT REFERENCES artists(id),
+	album_id  TEXT REFERENCES albums(id),
+	format    TEXT,
+	mtime     INTEGER NOT NULL,
+	size      INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracks_album ON tracks(album_id);
+CREATE INDEX IF NOT EXISTS idx_albums_artist ON albums(artist_id);
+`
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures its schema is up to date.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("persistence: open db: %w", err)
+	}
+	db.SetMaxOpenConns(1) // modernc.org/sqlite is not safe for concurrent writers
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("persistence: migrate schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// UpsertArtist inserts the artist if it doesn't already exist and returns
+// its stable ID.
+func (s *Store) UpsertArtist(name string) (string, error) {
+	id := HashID("artist", name)
+	_, err := s.db.Exec(`INSERT INTO artists (id, name) VALUES (?, ?)
+		ON CONFLICT(id) DO NOTHING`, id, name)
+	if err != nil {
+		return "", fmt.Errorf("persistence: upsert artist: %w", err)
+	}
+	return id, nil
+}
+
+// UpsertAlbum inserts the album if it doesn't already exist and returns
+// its stable ID.
+func (s *Store) UpsertAlbum(name, artistID string) (string, error) {
+	id := HashID("album", artistID, name)
+	_, err := s.db.Exec(`INSERT INTO albums (id, name, artist_id) VALUES (?, ?, ?)
+		ON CONFLICT(id) DO NOTHING`, id, name, artistID)
+	if err != nil {
+		return "", fmt.Errorf("persistence: upsert album: %w", err)
+	}
+	return id, nil
+}
+
+// TrackMeta is the bookkeeping the scanner needs alongside a Track to
+// decide whether a file has changed since it was last indexed.
+type TrackMeta struct {
+	MTime int64
+	Size  int64
+}
+
+// NeedsScan reports whether path is missing from the index or whether its
+// stored mtime/size differ from the current filesystem state.
+func (s *Store) NeedsScan(path string, meta TrackMeta) (bool, error) {
+	var mtime, size int64
+	err := s.db.QueryRow(`SELECT mtime, size FROM tracks WHERE path = ?`, path).Scan(&mtime, &size)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("persistence: check track: %w", err)
+	}
+	return mtime != meta.MTime || size != meta.Size, nil
+}
+
+// UpsertTrack inserts or updates a track row keyed by its path, assigning
+// a stable hash ID on first insert.
+func (s *Store) UpsertTrack(t models.Track, meta TrackMeta) (models.Track, error) {
+	id := HashID("track", t.Path)
+	t.ID = id
+	t.ArtworkID = id
+	_, err := s.db.Exec(`
+		INSERT INTO tracks (id, path, title, artist_id, album_id, format, mtime, size)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(path) DO UPDATE SET
+			title = excluded.title,
+			artist_id = excluded.artist_id,
+			album_id = excluded.album_id,
+			format = excluded.format,
+			mtime = excluded.mtime,
+			size = excluded.size
+	`, id, t.Path, t.Title, t.ArtistID, t.AlbumID, t.Format, meta.MTime, meta.Size)
+	if err != nil {
+		return models.Track{}, fmt.Errorf("persistence: upsert track: %w", err)
+	}
+	return t, nil
+}
+
+// DeleteTrackByPath removes the track indexed at path, e.g. after the
+// scanner observes a filesystem deletion.
+func (s *Store) DeleteTrackByPath(path string) error {
+	if _, err := s.db.Exec(`DELETE FROM tracks WHERE path = ?`, path); err != nil {
+		return fmt.Errorf("persistence: delete track: %w", err)
+	}
+	return nil
+}
+
+// GetArtist resolves an artist from the index by its stable ID.
+func (s *Store) GetArtist(id string) (models.Artist, error) {
+	var a models.Artist
+	err := s.db.QueryRow(`SELECT id, name FROM artists WHERE id = ?`, id).Scan(&a.ID, &a.Name)
+	if err == sql.ErrNoRows {
+		return models.Artist{}, fmt.Errorf("persistence: artist %q not found", id)
+	}
+	if err != nil {
+		return models.Artist{}, fmt.Errorf("persistence: get artist: %w", err)
+	}
+	return a, nil
+}
+
+// ListArtists returns every indexed artist, ordered by name.
+func (s *Store) ListArtists() ([]models.Artist, error) {
+	rows, err := s.db.Query(`SELECT id, name FROM artists ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("persistence: list artists: %w", err)
+	}
+	defer rows.Close()
+
+	var artists []models.Artist
+	for rows.Next() {
+		var a models.Artist
+		if err := rows.Scan(&a.ID, &a.Name); err != nil {
+			return nil, fmt.Errorf("persistence: scan artist: %w", err)
+		}
+		artists = append(artists, a)
+	}
+	return artists, rows.Err()
+}
+
+const albumSelect = `
+	SELECT al.id, al.name, al.artist_id
+	FROM albums al
+`
+
+func scanAlbum(row interface{ Scan(...any) error }) (models.Album, error) {
+	var a models.Album
+	err := row.Scan(&a.ID, &a.Name, &a.ArtistID)
+	return a, err
+}
+
+// GetAlbum resolves an album from the index by its stable ID.
+func (s *Store) GetAlbum(id string) (models.Album, error) {
+	row := s.db.QueryRow(albumSelect+` WHERE al.id = ?`, id)
+	a, err := scanAlbum(row)
+	if err == sql.ErrNoRows {
+		return models.Album{}, fmt.Errorf("persistence: album %q not found", id)
+	}
+	if err != nil {
+		return models.Album{}, fmt.Errorf("persistence: get album: %w", err)
+	}
+	return a, nil
+}
+
+// ListAlbums returns every indexed album, ordered by name.
+func (s *Store) ListAlbums() ([]models.Album, error) {
+	rows, err := s.db.Query(albumSelect + ` ORDER BY al.name`)
+	if err != nil {
+		return nil, fmt.Errorf("persistence: list albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []models.Album
+	for rows.Next() {
+		a, err := scanAlbum(rows)
+		if err != nil {
+			return nil, fmt.Errorf("persistence: scan album: %w", err)
+		}
+		albums = append(albums, a)
+	}
+	return albums, rows.Err()
+}
+
+// ListTracksByAlbum returns the tracks on the given album, ordered by title.
+func (s *Store) ListTracksByAlbum(albumID string) ([]models.Track, error) {
+	rows, err := s.db.Query(trackSelect+` WHERE t.album_id = ? ORDER BY t.title`, albumID)
+	if err != nil {
+		return nil, fmt.Errorf("persistence: list tracks by album: %w", err)
+	}
+	defer rows.Close()
+
+	var tracks []models.Track
+	for rows.Next() {
+		t, err := scanTrack(rows)
+		if err != nil {
+			return nil, fmt.Errorf("persistence: scan track: %w", err)
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, rows.Err()
+}
+
+// Search3 does a simple substring search across artists, albums, and
+// tracks, matching the Subsonic search3 endpoint's three result buckets.
+func (s *Store) Search3(query string) (artists []models.Artist, albums []models.Album, tracks []models.Track, err error) {
+	like := "%" + query + "%"
+
+	artistRows, err := s.db.Query(`SELECT id, name FROM artists WHERE name LIKE ? ORDER BY name`, like)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("persistence: search artists: %w", err)
+	}
+	defer artistRows.Close()
+	for artistRows.Next() {
+		var a models.Artist
+		if err := artistRows.Scan(&a.ID, &a.Name); err != nil {
+			return nil, nil, nil, fmt.Errorf("persistence: scan artist: %w", err)
+		}
+		artists = append(artists, a)
+	}
+
+	albumRows, err := s.db.Query(albumSelect+` WHERE al.name LIKE ? ORDER BY al.name`, like)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("persistence: search albums: %w", err)
+	}
+	defer albumRows.Close()
+	for albumRows.Next() {
+		a, err := scanAlbum(albumRows)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("persistence: scan album: %w", err)
+		}
+		albums = append(albums, a)
+	}
+
+	trackRows, err := s.db.Query(trackSelect+` WHERE t.title LIKE ? ORDER BY t.title`, like)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("persistence: search tracks: %w", err)
+	}
+	defer trackRows.Close()
+	for trackRows.Next() {
+		t, err := scanTrack(trackRows)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("persistence: scan track: %w", err)
+		}
+		tracks = append(tracks, t)
+	}
+
+	return artists, albums, tracks, nil
+}
+
+const trackSelect = `
+	SELECT t.id, t.title, COALESCE(ar.name, ''), t.artist_id, COALESCE(al.name, ''), t.album_id,
+	       t.path, t.format
+	FROM tracks t
+	LEFT JOIN artists ar ON ar.id = t.artist_id
+	LEFT JOIN albums al ON al.id = t.album_id
+`
+
+func scanTrack(row interface{ Scan(...any) error }) (models.Track, error) {
+	var t models.Track
+	if err := row.Scan(&t.ID, &t.Title, &t.Artist, &t.ArtistID, &t.Album, &t.AlbumID, &t.Path, &t.Format); err != nil {
+		return models.Track{}, err
+	}
+	t.ArtworkID = t.ID
+	return t, nil
+}
+
+// GetTrackByID resolves a track from the index by its stable ID.
+func (s *Store) GetTrackByID(id string) (models.Track, error) {
+	row := s.db.QueryRow(trackSelect+` WHERE t.id = ?`, id)
+	t, err := scanTrack(row)
+	if err == sql.ErrNoRows {
+		return models.Track{}, fmt.Errorf("persistence: track %q not found", id)
+	}
+	if err != nil {
+		return models.Track{}, fmt.Errorf("persistence: get track: %w", err)
+	}
+	return t, nil
+}
+
+// ListTracks returns every indexed track, ordered by album then title.
+func (s *Store) ListTracks() ([]models.Track, error) {
+	rows, err := s.db.Query(trackSelect + ` ORDER BY al.name, t.title`)
+	if err != nil {
+		return nil, fmt.Errorf("persistence: list tracks: %w", err)
+	}
+	defer rows.Close()
+
+	var tracks []models.Track
+	for rows.Next() {
+		t, err := scanTrack(rows)
+		if err != nil {
+			return nil, fmt.Errorf("persistence: scan track: %w", err)
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, rows.Err()
+}
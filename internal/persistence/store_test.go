@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"testing"
+
+	"yhsrv/internal/models"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestNeedsScan(t *testing.T) {
+	s := openTestStore(t)
+
+	meta := TrackMeta{MTime: 100, Size: 2048}
+	needs, err := s.NeedsScan("/music/a.mp3", meta)
+	if err != nil {
+		t.Fatalf("NeedsScan: %v", err)
+	}
+	if !needs {
+		t.Fatal("NeedsScan() = false for an unindexed path, want true")
+	}
+
+	if _, err := s.UpsertTrack(models.Track{Path: "/music/a.mp3", Title: "A"}, meta); err != nil {
+		t.Fatalf("UpsertTrack: %v", err)
+	}
+
+	needs, err = s.NeedsScan("/music/a.mp3", meta)
+	if err != nil {
+		t.Fatalf("NeedsScan: %v", err)
+	}
+	if needs {
+		t.Fatal("NeedsScan() = true for an unchanged, already-indexed file, want false")
+	}
+
+	changed := TrackMeta{MTime: 200, Size: 2048}
+	needs, err = s.NeedsScan("/music/a.mp3", changed)
+	if err != nil {
+		t.Fatalf("NeedsScan: %v", err)
+	}
+	if !needs {
+		t.Fatal("NeedsScan() = false after mtime changed, want true")
+	}
+}
+
+func TestUpsertTrackIdempotent(t *testing.T) {
+	s := openTestStore(t)
+	meta := TrackMeta{MTime: 100, Size: 2048}
+
+	first, err := s.UpsertTrack(models.Track{Path: "/music/a.mp3", Title: "A"}, meta)
+	if err != nil {
+		t.Fatalf("UpsertTrack: %v", err)
+	}
+
+	second, err := s.UpsertTrack(models.Track{Path: "/music/a.mp3", Title: "A (retagged)"}, meta)
+	if err != nil {
+		t.Fatalf("UpsertTrack: %v", err)
+	}
+
+	if first.ID != second.ID {
+		t.Fatalf("UpsertTrack assigned a different ID on re-scan: %q vs %q", first.ID, second.ID)
+	}
+
+	got, err := s.GetTrackByID(first.ID)
+	if err != nil {
+		t.Fatalf("GetTrackByID: %v", err)
+	}
+	if got.Title != "A (retagged)" {
+		t.Fatalf("GetTrackByID().Title = %q, want the re-scanned title", got.Title)
+	}
+
+	tracks, err := s.ListTracks()
+	if err != nil {
+		t.Fatalf("ListTracks: %v", err)
+	}
+	if len(tracks) != 1 {
+		t.Fatalf("ListTracks() returned %d tracks after re-upserting the same path, want 1", len(tracks))
+	}
+}
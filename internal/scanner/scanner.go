@@ -0,0 +1,177 @@
+// Package scanner walks a music library on disk and keeps the
+// persistence index in sync with it, both via an initial full scan and
+// incrementally via filesystem notifications.
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"yhsrv/internal/models"
+	"yhsrv/internal/persistence"
+
+	"github.com/dhowden/tag"
+	"github.com/fsnotify/fsnotify"
+)
+
+var validExts = map[string]bool{
+	".mp3": true, ".flac": true, ".wav": true, ".m4a": true, ".aac": true, ".ogg": true,
+}
+
+// Scanner indexes an on-disk music library into a persistence.Store.
+type Scanner struct {
+	store *persistence.Store
+	dir   string
+}
+
+// New returns a Scanner that indexes dir into store.
+func New(store *persistence.Store, dir string) *Scanner {
+	return &Scanner{store: store, dir: dir}
+}
+
+// ScanAll walks the library directory and indexes every audio file whose
+// mtime/size has changed since it was last indexed. It is safe to call
+// repeatedly; unchanged files are skipped without re-reading tags.
+func (s *Scanner) ScanAll(ctx context.Context) error {
+	return filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return fmt.Errorf("scanner: walk %s: %w", path, err)
+		}
+		if info.IsDir() || !validExts[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		if err := s.scanFile(path, info); err != nil {
+			log.Printf("scanner: skipping %s: %v", path, err)
+		}
+		return nil
+	})
+}
+
+func (s *Scanner) scanFile(path string, info os.FileInfo) error {
+	meta := persistence.TrackMeta{MTime: info.ModTime().Unix(), Size: info.Size()}
+
+	needsScan, err := s.store.NeedsScan(path, meta)
+	if err != nil {
+		return err
+	}
+	if !needsScan {
+		return nil
+	}
+
+	track, err := readTags(path)
+	if err != nil {
+		return err
+	}
+
+	artistID, err := s.store.UpsertArtist(track.Artist)
+	if err != nil {
+		return err
+	}
+	albumID, err := s.store.UpsertAlbum(track.Album, artistID)
+	if err != nil {
+		return err
+	}
+	track.ArtistID, track.AlbumID = artistID, albumID
+
+	if _, err := s.store.UpsertTrack(track, meta); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readTags extracts title/artist/album/format metadata for a single file.
+// Artwork is intentionally not handled here; it's served separately by
+// internal/artwork.
+func readTags(path string) (models.Track, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return models.Track{}, err
+	}
+	defer f.Close()
+
+	md, err := tag.ReadFrom(f)
+	if err != nil {
+		return models.Track{}, fmt.Errorf("read tags: %w", err)
+	}
+
+	return models.Track{
+		Path:   path,
+		Title:  md.Title(),
+		Artist: md.Artist(),
+		Album:  md.Album(),
+		Format: strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")),
+	}, nil
+}
+
+// Watch watches the library directory tree for changes and incrementally
+// updates the index, until ctx is canceled. Creates and writes trigger a
+// rescan of the affected file; removes and renames delete its index entry.
+func (s *Scanner) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("scanner: create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("scanner: watch %s: %w", s.dir, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			s.handleEvent(watcher, event)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("scanner: watch error: %v", err)
+		}
+	}
+}
+
+func (s *Scanner) handleEvent(watcher *fsnotify.Watcher, event fsnotify.Event) {
+	if !validExts[strings.ToLower(filepath.Ext(event.Name))] {
+		if event.Op&fsnotify.Create != 0 {
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				watcher.Add(event.Name)
+			}
+		}
+		return
+	}
+
+	switch {
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		if err := s.store.DeleteTrackByPath(event.Name); err != nil {
+			log.Printf("scanner: remove %s: %v", event.Name, err)
+		}
+
+	case event.Op&(fsnotify.Create|fsnotify.Write) != 0:
+		info, err := os.Stat(event.Name)
+		if err != nil {
+			return
+		}
+		if err := s.scanFile(event.Name, info); err != nil {
+			log.Printf("scanner: rescan %s: %v", event.Name, err)
+		}
+	}
+}
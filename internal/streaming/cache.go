@@ -0,0 +1,29 @@
+package streaming
+
+import (
+	"fmt"
+
+	"yhsrv/internal/diskcache"
+)
+
+// TranscodeCache stores transcoded audio on disk, keyed by
+// (trackID, format, bitrate), evicting least-recently-used entries once
+// the total size exceeds maxBytes.
+type TranscodeCache struct {
+	*diskcache.Cache
+}
+
+// NewTranscodeCache creates a cache rooted at dir, creating it if necessary.
+// maxBytes <= 0 disables eviction.
+func NewTranscodeCache(dir string, maxBytes int64) (*TranscodeCache, error) {
+	c, err := diskcache.New(dir, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("streaming: %w", err)
+	}
+	return &TranscodeCache{c}, nil
+}
+
+// Key builds the cache key for a given track/format/bitrate combination.
+func Key(trackID, format string, bitrate int) string {
+	return fmt.Sprintf("%s.%s.%d", trackID, format, bitrate)
+}
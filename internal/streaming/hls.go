@@ -0,0 +1,193 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/grafov/m3u8"
+	"golang.org/x/sync/singleflight"
+)
+
+// segmentSeconds is the target duration ffmpeg segments media into, and
+// what TargetDuration/#EXTINF advertise to clients.
+const segmentSeconds = 10
+
+// Variant is one quality rung of an HLS master playlist.
+type Variant struct {
+	Name    string // used as the path segment, e.g. "96k"
+	Bitrate int    // kbps; 0 means passthrough (no re-encode)
+	Codec   string // RFC 6381 codec string advertised in the master playlist
+}
+
+// DefaultVariants mirrors a typical Subsonic-style client's expectations:
+// two transcoded AAC rungs plus the original file, untouched.
+var DefaultVariants = []Variant{
+	{Name: "96k", Bitrate: 96, Codec: "mp4a.40.2"},
+	{Name: "192k", Bitrate: 192, Codec: "mp4a.40.2"},
+	{Name: "original", Bitrate: 0},
+}
+
+// HLS generates HLS playlists and lazily transcodes their segments,
+// caching the results on disk and deduplicating concurrent requests for
+// the same (track, variant) into a single ffmpeg run.
+type HLS struct {
+	baseDir string
+	sf      singleflight.Group
+}
+
+// NewHLS returns an HLS segmenter rooted at baseDir.
+func NewHLS(baseDir string) *HLS {
+	return &HLS{baseDir: baseDir}
+}
+
+// MasterPlaylist builds the top-level playlist listing each variant's own
+// media playlist URL.
+func (h *HLS) MasterPlaylist(trackID string, variants []Variant) []byte {
+	master := m3u8.NewMasterPlaylist()
+	for _, v := range variants {
+		master.Append(fmt.Sprintf("/hls/%s/%s/index.m3u8", trackID, v.Name), nil, m3u8.VariantParams{
+			Bandwidth: uint32(v.Bitrate * 1000),
+			Codecs:    v.Codec,
+		})
+	}
+	return master.Encode().Bytes()
+}
+
+func (h *HLS) variantDir(trackID, variant string) string {
+	return filepath.Join(h.baseDir, trackID, variant)
+}
+
+// VariantPlaylist builds the media playlist for one variant of a track,
+// probing its duration to work out how many 10s segments it covers.
+func (h *HLS) VariantPlaylist(ctx context.Context, trackID, variant, srcPath string, variants []Variant) ([]byte, error) {
+	if _, ok := findVariant(variants, variant); !ok {
+		return nil, fmt.Errorf("streaming: unknown variant %q for track %q", variant, trackID)
+	}
+
+	duration, err := probeDuration(ctx, srcPath)
+	if err != nil {
+		return nil, err
+	}
+	segmentCount := int(math.Ceil(duration / segmentSeconds))
+	if segmentCount < 1 {
+		segmentCount = 1
+	}
+
+	pl, err := m3u8.NewMediaPlaylist(uint(segmentCount), uint(segmentCount))
+	if err != nil {
+		return nil, fmt.Errorf("streaming: new media playlist: %w", err)
+	}
+	pl.MediaType = m3u8.VOD
+	pl.TargetDuration = segmentSeconds
+
+	remaining := duration
+	for i := 0; i < segmentCount; i++ {
+		segDuration := float64(segmentSeconds)
+		if remaining < segDuration {
+			segDuration = remaining
+		}
+		if err := pl.Append(fmt.Sprintf("seg-%d.ts", i), segDuration, ""); err != nil {
+			return nil, fmt.Errorf("streaming: append segment: %w", err)
+		}
+		remaining -= segDuration
+	}
+	pl.Close() // writes #EXT-X-ENDLIST, since the whole file is known up front (VOD)
+
+	return pl.Encode().Bytes(), nil
+}
+
+// Segment returns the on-disk path of segment n of (trackID, variant),
+// transcoding the whole variant into segments on first request. Concurrent
+// requests for the same variant collapse onto a single ffmpeg run.
+func (h *HLS) Segment(ctx context.Context, trackID, variant string, n int, srcPath string, variants []Variant) (string, error) {
+	dir := h.variantDir(trackID, variant)
+	segPath := filepath.Join(dir, fmt.Sprintf("seg-%d.ts", n))
+	if _, err := os.Stat(segPath); err == nil {
+		return segPath, nil
+	}
+
+	v, ok := findVariant(variants, variant)
+	if !ok {
+		return "", fmt.Errorf("streaming: unknown variant %q", variant)
+	}
+
+	key := trackID + "/" + variant
+	_, err, _ := h.sf.Do(key, func() (any, error) {
+		if _, err := os.Stat(segPath); err == nil {
+			return nil, nil // another request already produced it while we waited
+		}
+		return nil, h.segmentVariant(ctx, srcPath, dir, v)
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(segPath); err != nil {
+		return "", fmt.Errorf("streaming: segment %d of %s/%s was not produced", n, trackID, variant)
+	}
+	return segPath, nil
+}
+
+func (h *HLS) segmentVariant(ctx context.Context, srcPath, dir string, v Variant) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("streaming: create segment dir: %w", err)
+	}
+
+	args := []string{"-i", srcPath}
+	if v.Bitrate > 0 {
+		args = append(args, "-codec:a", "aac", "-b:a", strconv.Itoa(v.Bitrate)+"k")
+	} else {
+		args = append(args, "-codec", "copy")
+	}
+	args = append(args,
+		"-f", "segment",
+		"-segment_time", strconv.Itoa(segmentSeconds),
+		"-reset_timestamps", "1",
+		filepath.Join(dir, "seg-%d.ts"),
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("streaming: ffmpeg segment: %w: %s", err, out)
+	}
+	return nil
+}
+
+func findVariant(variants []Variant, name string) (Variant, bool) {
+	for _, v := range variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+// probeDuration shells out to ffprobe to get a source file's duration in
+// seconds.
+func probeDuration(ctx context.Context, path string) (float64, error) {
+	cmd := exec.CommandContext(ctx, "ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", path)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("streaming: ffprobe: %w", err)
+	}
+
+	var parsed struct {
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return 0, fmt.Errorf("streaming: parse ffprobe output: %w", err)
+	}
+
+	duration, err := strconv.ParseFloat(parsed.Format.Duration, 64)
+	if err != nil {
+		return 0, fmt.Errorf("streaming: parse duration: %w", err)
+	}
+	return duration, nil
+}
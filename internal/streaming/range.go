@@ -0,0 +1,111 @@
+// Package streaming implements HTTP Range parsing and a transcoding
+// pipeline for serving audio files efficiently.
+package streaming
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRange is returned when a Range header cannot be parsed.
+var ErrInvalidRange = errors.New("streaming: invalid range")
+
+// ErrUnsatisfiableRange is returned when a Range header is syntactically
+// valid but does not intersect the resource.
+var ErrUnsatisfiableRange = errors.New("streaming: range not satisfiable")
+
+// Range is a single byte range, resolved against a concrete resource size.
+// Start and End are both inclusive.
+type Range struct {
+	Start int64
+	End   int64
+}
+
+// Length returns the number of bytes covered by the range.
+func (r Range) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// ParseRanges parses an HTTP Range header value (e.g. "bytes=0-1023" or
+// "bytes=1024-,2048-4095") against a resource of the given size. It supports
+// open-ended ranges ("N-") and suffix ranges ("-N"), and returns one Range
+// per comma-separated unit, in order. A nil, empty header is not an error;
+// it simply yields no ranges, meaning "serve the whole resource".
+func ParseRanges(header string, size int64) ([]Range, error) {
+	if header == "" {
+		return nil, nil
+	}
+	if size <= 0 {
+		return nil, ErrUnsatisfiableRange
+	}
+
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, ErrInvalidRange
+	}
+
+	var ranges []Range
+	for _, part := range strings.Split(header[len(prefix):], ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		dash := strings.IndexByte(part, '-')
+		if dash < 0 {
+			return nil, ErrInvalidRange
+		}
+		startStr, endStr := strings.TrimSpace(part[:dash]), strings.TrimSpace(part[dash+1:])
+
+		var r Range
+		switch {
+		case startStr == "" && endStr == "":
+			return nil, ErrInvalidRange
+		case startStr == "":
+			// Suffix range: last N bytes.
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidRange
+			}
+			if n == 0 {
+				continue // unsatisfiable suffix of zero bytes, ignore per RFC 7233
+			}
+			if n > size {
+				n = size
+			}
+			r = Range{Start: size - n, End: size - 1}
+		case endStr == "":
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, ErrInvalidRange
+			}
+			if start >= size {
+				return nil, ErrUnsatisfiableRange
+			}
+			r = Range{Start: start, End: size - 1}
+		default:
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, ErrInvalidRange
+			}
+			end, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || end < start {
+				return nil, ErrInvalidRange
+			}
+			if start >= size {
+				return nil, ErrUnsatisfiableRange
+			}
+			if end >= size {
+				end = size - 1
+			}
+			r = Range{Start: start, End: end}
+		}
+		ranges = append(ranges, r)
+	}
+
+	if len(ranges) == 0 {
+		return nil, ErrUnsatisfiableRange
+	}
+	return ranges, nil
+}
@@ -0,0 +1,108 @@
+package streaming
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRanges(t *testing.T) {
+	const size = 1000
+
+	tests := []struct {
+		name    string
+		header  string
+		want    []Range
+		wantErr error
+	}{
+		{
+			name:   "no header",
+			header: "",
+			want:   nil,
+		},
+		{
+			name:   "simple range",
+			header: "bytes=0-499",
+			want:   []Range{{Start: 0, End: 499}},
+		},
+		{
+			name:   "open-ended range",
+			header: "bytes=500-",
+			want:   []Range{{Start: 500, End: 999}},
+		},
+		{
+			name:   "suffix range",
+			header: "bytes=-200",
+			want:   []Range{{Start: 800, End: 999}},
+		},
+		{
+			name:   "suffix range larger than resource",
+			header: "bytes=-10000",
+			want:   []Range{{Start: 0, End: 999}},
+		},
+		{
+			name:   "end clamped to resource size",
+			header: "bytes=900-10000",
+			want:   []Range{{Start: 900, End: 999}},
+		},
+		{
+			name:   "multi-range",
+			header: "bytes=0-99,200-299",
+			want:   []Range{{Start: 0, End: 99}, {Start: 200, End: 299}},
+		},
+		{
+			name:    "missing prefix",
+			header:  "0-499",
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "malformed unit",
+			header:  "bytes=abc-499",
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "end before start",
+			header:  "bytes=500-100",
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "start beyond resource",
+			header:  "bytes=1000-1999",
+			wantErr: ErrUnsatisfiableRange,
+		},
+		{
+			name:    "zero-length suffix ignored, leaving nothing satisfiable",
+			header:  "bytes=-0",
+			wantErr: ErrUnsatisfiableRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRanges(tt.header, size)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseRanges(%q) error = %v, want %v", tt.header, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRanges(%q) unexpected error: %v", tt.header, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseRanges(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ParseRanges(%q)[%d] = %v, want %v", tt.header, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRangeLength(t *testing.T) {
+	r := Range{Start: 10, End: 19}
+	if got, want := r.Length(), int64(10); got != want {
+		t.Fatalf("Length() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package streaming
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+)
+
+// ServeFile serves the file at path over w/r, honoring Range requests
+// (including multi-range, served as multipart/byteranges) and setting
+// Accept-Ranges so clients know seeking is supported.
+func ServeFile(w http.ResponseWriter, r *http.Request, path, contentType string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := stat.Size()
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("Content-Type", contentType)
+
+	ranges, err := ParseRanges(r.Header.Get("Range"), size)
+	if err != nil {
+		if err == ErrUnsatisfiableRange {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return nil
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		return nil
+	}
+
+	switch len(ranges) {
+	case 0:
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		w.WriteHeader(http.StatusOK)
+		_, err = io.Copy(w, f)
+		return err
+
+	case 1:
+		rg := ranges[0]
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rg.Start, rg.End, size))
+		w.Header().Set("Content-Length", strconv.FormatInt(rg.Length(), 10))
+		w.WriteHeader(http.StatusPartialContent)
+		if _, err := f.Seek(rg.Start, io.SeekStart); err != nil {
+			return err
+		}
+		_, err = io.CopyN(w, f, rg.Length())
+		return err
+
+	default:
+		return serveMultipartRanges(w, f, ranges, size, contentType)
+	}
+}
+
+func serveMultipartRanges(w http.ResponseWriter, f *os.File, ranges []Range, size int64, contentType string) error {
+	pr, pw := io.Pipe()
+	mpw := multipart.NewWriter(pw)
+
+	go func() {
+		var err error
+		for _, rg := range ranges {
+			hdr := textproto.MIMEHeader{}
+			hdr.Set("Content-Type", contentType)
+			hdr.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rg.Start, rg.End, size))
+			var part io.Writer
+			part, err = mpw.CreatePart(hdr)
+			if err != nil {
+				break
+			}
+			if _, err = f.Seek(rg.Start, io.SeekStart); err != nil {
+				break
+			}
+			if _, err = io.CopyN(part, f, rg.Length()); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = mpw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mpw.Boundary())
+	w.WriteHeader(http.StatusPartialContent)
+	_, err := io.Copy(w, pr)
+	return err
+}
@@ -0,0 +1,44 @@
+package subsonic
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// authenticate validates the Subsonic u/t/s (token+salt) or u/p
+// (cleartext/hex, optionally "enc:"-prefixed) credentials against the
+// configured user/password. If no password is configured, the server is
+// treated as open (handy for a local, single-user setup).
+func (rt *Router) authenticate(r *http.Request) bool {
+	if rt.password == "" {
+		return true
+	}
+
+	q := r.URL.Query()
+	if subtle.ConstantTimeCompare([]byte(q.Get("u")), []byte(rt.user)) != 1 {
+		return false
+	}
+
+	if token := q.Get("t"); token != "" {
+		sum := md5.Sum([]byte(rt.password + q.Get("s")))
+		expected := hex.EncodeToString(sum[:])
+		return hmac.Equal([]byte(strings.ToLower(token)), []byte(expected))
+	}
+
+	if password := q.Get("p"); password != "" {
+		if encoded, ok := strings.CutPrefix(password, "enc:"); ok {
+			decoded, err := hex.DecodeString(encoded)
+			if err != nil {
+				return false
+			}
+			return hmac.Equal(decoded, []byte(rt.password))
+		}
+		return hmac.Equal([]byte(password), []byte(rt.password))
+	}
+
+	return false
+}
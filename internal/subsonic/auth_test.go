@@ -0,0 +1,91 @@
+package subsonic
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	rt := &Router{user: "alice", password: "hunter2"}
+
+	tokenSaltSum := md5.Sum([]byte(rt.password + "saltvalue"))
+	validToken := hex.EncodeToString(tokenSaltSum[:])
+
+	tests := []struct {
+		name   string
+		params url.Values
+		want   bool
+	}{
+		{
+			name:   "valid token+salt",
+			params: url.Values{"u": {"alice"}, "t": {validToken}, "s": {"saltvalue"}},
+			want:   true,
+		},
+		{
+			name:   "token+salt is case-insensitive hex",
+			params: url.Values{"u": {"alice"}, "t": {strings.ToUpper(validToken)}, "s": {"saltvalue"}},
+			want:   true,
+		},
+		{
+			name:   "wrong token",
+			params: url.Values{"u": {"alice"}, "t": {"deadbeef"}, "s": {"saltvalue"}},
+			want:   false,
+		},
+		{
+			name:   "cleartext password",
+			params: url.Values{"u": {"alice"}, "p": {"hunter2"}},
+			want:   true,
+		},
+		{
+			name:   "wrong cleartext password",
+			params: url.Values{"u": {"alice"}, "p": {"wrong"}},
+			want:   false,
+		},
+		{
+			name:   "enc: hex-encoded password",
+			params: url.Values{"u": {"alice"}, "p": {"enc:" + hex.EncodeToString([]byte("hunter2"))}},
+			want:   true,
+		},
+		{
+			name:   "enc: with wrong password",
+			params: url.Values{"u": {"alice"}, "p": {"enc:" + hex.EncodeToString([]byte("wrong"))}},
+			want:   false,
+		},
+		{
+			name:   "enc: with invalid hex is rejected, not compared raw",
+			params: url.Values{"u": {"alice"}, "p": {"enc:not-hex"}},
+			want:   false,
+		},
+		{
+			name:   "wrong user",
+			params: url.Values{"u": {"bob"}, "p": {"hunter2"}},
+			want:   false,
+		},
+		{
+			name:   "no credentials",
+			params: url.Values{},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/rest/ping.view?"+tt.params.Encode(), nil)
+			if got := rt.authenticate(r); got != tt.want {
+				t.Fatalf("authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateOpenWhenNoPasswordConfigured(t *testing.T) {
+	rt := &Router{}
+	r := httptest.NewRequest("GET", "/rest/ping.view", nil)
+	if !rt.authenticate(r) {
+		t.Fatal("authenticate() = false with no password configured, want true (open server)")
+	}
+}
@@ -0,0 +1,59 @@
+package subsonic
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"yhsrv/internal/agents"
+	"yhsrv/internal/models"
+)
+
+func toSubsonicAlbum(a models.Album, songs []Song) Album {
+	return Album{
+		ID:        a.ID,
+		Name:      a.Name,
+		ArtistID:  a.ArtistID,
+		CoverArt:  a.ID,
+		SongCount: len(songs),
+		Song:      songs,
+	}
+}
+
+func toSubsonicSong(t models.Track) Song {
+	return Song{
+		ID:       t.ID,
+		Title:    t.Title,
+		Album:    t.Album,
+		AlbumID:  t.AlbumID,
+		Artist:   t.Artist,
+		ArtistID: t.ArtistID,
+		CoverArt: t.ArtworkID,
+		Suffix:   t.Format,
+	}
+}
+
+// lyricsText flattens Lyrics into the plain text the classic getLyrics
+// endpoint expects, dropping line timestamps if the source was synced.
+func lyricsText(l agents.Lyrics) string {
+	if !l.Synced {
+		return l.Plain
+	}
+	lines := make([]string, len(l.Lines))
+	for i, line := range l.Lines {
+		lines[i] = line.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package subsonic
+
+// Error is the Subsonic API's error payload, returned when status="failed".
+type Error struct {
+	Code    int    `xml:"code,attr" json:"code"`
+	Message string `xml:"message,attr" json:"message"`
+}
+
+// MusicFolder is a top-level library root, as exposed by getMusicFolders.
+type MusicFolder struct {
+	ID   string `xml:"id,attr" json:"id"`
+	Name string `xml:"name,attr" json:"name"`
+}
+
+// MusicFolders wraps the getMusicFolders result.
+type MusicFolders struct {
+	Folder []MusicFolder `xml:"musicFolder" json:"musicFolder"`
+}
+
+// Artist is an artist entry as it appears nested under an Index.
+type Artist struct {
+	ID   string `xml:"id,attr" json:"id"`
+	Name string `xml:"name,attr" json:"name"`
+}
+
+// Index groups artists under a single letter, as getIndexes returns them.
+type Index struct {
+	Name   string   `xml:"name,attr" json:"name"`
+	Artist []Artist `xml:"artist" json:"artist"`
+}
+
+// Indexes wraps the getIndexes result.
+type Indexes struct {
+	Index []Index `xml:"index" json:"index"`
+}
+
+// Album is an album entry in ID3-organized (artist/album/song) responses.
+type Album struct {
+	ID        string `xml:"id,attr" json:"id"`
+	Name      string `xml:"name,attr" json:"name"`
+	ArtistID  string `xml:"artistId,attr,omitempty" json:"artistId,omitempty"`
+	Artist    string `xml:"artist,attr,omitempty" json:"artist,omitempty"`
+	CoverArt  string `xml:"coverArt,attr,omitempty" json:"coverArt,omitempty"`
+	SongCount int    `xml:"songCount,attr" json:"songCount"`
+	Song      []Song `xml:"song,omitempty" json:"song,omitempty"`
+}
+
+// AlbumList2 wraps the getAlbumList2 result.
+type AlbumList2 struct {
+	Album []Album `xml:"album" json:"album"`
+}
+
+// Song is a single track entry, the unit returned by getSong and nested
+// under Album/SearchResult3.
+type Song struct {
+	ID       string `xml:"id,attr" json:"id"`
+	Title    string `xml:"title,attr" json:"title"`
+	Album    string `xml:"album,attr,omitempty" json:"album,omitempty"`
+	AlbumID  string `xml:"albumId,attr,omitempty" json:"albumId,omitempty"`
+	Artist   string `xml:"artist,attr,omitempty" json:"artist,omitempty"`
+	ArtistID string `xml:"artistId,attr,omitempty" json:"artistId,omitempty"`
+	CoverArt string `xml:"coverArt,attr,omitempty" json:"coverArt,omitempty"`
+	Suffix   string `xml:"suffix,attr,omitempty" json:"suffix,omitempty"`
+	IsDir    bool   `xml:"isDir,attr" json:"isDir"`
+}
+
+// SearchResult3 wraps the search3 result.
+type SearchResult3 struct {
+	Artist []Artist `xml:"artist,omitempty" json:"artist,omitempty"`
+	Album  []Album  `xml:"album,omitempty" json:"album,omitempty"`
+	Song   []Song   `xml:"song,omitempty" json:"song,omitempty"`
+}
+
+// Lyrics is the classic (non-ID3) getLyrics response: plain, unsynced text.
+type Lyrics struct {
+	Artist string `xml:"artist,attr,omitempty" json:"artist,omitempty"`
+	Title  string `xml:"title,attr,omitempty" json:"title,omitempty"`
+	Value  string `xml:",chardata" json:"value,omitempty"`
+}
@@ -0,0 +1,65 @@
+package subsonic
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+)
+
+const apiVersion = "1.16.1"
+
+// Envelope is the single root every Subsonic response is wrapped in; only
+// the field relevant to the call being answered is ever populated.
+type Envelope struct {
+	XMLName xml.Name `xml:"subsonic-response" json:"-"`
+	Status  string   `xml:"status,attr" json:"status"`
+	Version string   `xml:"version,attr" json:"version"`
+
+	Error         *Error         `xml:"error,omitempty" json:"error,omitempty"`
+	MusicFolders  *MusicFolders  `xml:"musicFolders,omitempty" json:"musicFolders,omitempty"`
+	Indexes       *Indexes       `xml:"indexes,omitempty" json:"indexes,omitempty"`
+	AlbumList2    *AlbumList2    `xml:"albumList2,omitempty" json:"albumList2,omitempty"`
+	Album         *Album         `xml:"album,omitempty" json:"album,omitempty"`
+	Song          *Song          `xml:"song,omitempty" json:"song,omitempty"`
+	SearchResult3 *SearchResult3 `xml:"searchResult3,omitempty" json:"searchResult3,omitempty"`
+	Lyrics        *Lyrics        `xml:"lyrics,omitempty" json:"lyrics,omitempty"`
+}
+
+// jsonEnvelope mirrors the real API's JSON shape, which wraps Envelope's
+// fields under a "subsonic-response" key instead of at the top level.
+type jsonEnvelope struct {
+	Envelope Envelope `json:"subsonic-response"`
+}
+
+// writeResponse renders env as XML or JSON depending on the request's
+// f= parameter, per the Subsonic API's dual response format.
+func writeResponse(w http.ResponseWriter, r *http.Request, env Envelope) {
+	if env.Status == "" {
+		env.Status = "ok"
+	}
+	env.Version = apiVersion
+
+	if r.URL.Query().Get("f") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jsonEnvelope{Envelope: env})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(env)
+}
+
+// writeError renders a failed response with the given Subsonic error code.
+func writeError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	writeResponse(w, r, Envelope{Status: "failed", Error: &Error{Code: code, Message: message}})
+}
+
+// Subsonic error codes used by this server.
+const (
+	ErrorGeneric          = 0
+	ErrorMissingParam     = 10
+	ErrorWrongCredentials = 40
+	ErrorNotAuthorized    = 50
+	ErrorDataNotFound     = 70
+)
@@ -0,0 +1,299 @@
+// Package subsonic exposes a Subsonic/OpenSubsonic-compatible REST API
+// (https://opensubsonic.netlify.app) on top of the server's library index,
+// so existing Subsonic clients (DSub, play:Sub, Symfonium, Feishin, ...)
+// can browse and stream from it without a bespoke UI.
+package subsonic
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"yhsrv/internal/agents"
+	"yhsrv/internal/artwork"
+	"yhsrv/internal/persistence"
+	"yhsrv/internal/streaming"
+
+	"go.oneofone.dev/gserv"
+)
+
+// Router holds the dependencies the Subsonic handlers need and serves as
+// the receiver for each endpoint method.
+type Router struct {
+	library *persistence.Store
+	artwork *artwork.Service
+	agents  *agents.Manager
+
+	user     string
+	password string
+}
+
+// New returns a Router backed by library, artwork, and agents. Credentials
+// are read from SUBSONIC_USER/SUBSONIC_PASSWORD; if unset, auth is
+// disabled.
+func New(library *persistence.Store, art *artwork.Service, mgr *agents.Manager) *Router {
+	return &Router{
+		library:  library,
+		artwork:  art,
+		agents:   mgr,
+		user:     os.Getenv("SUBSONIC_USER"),
+		password: os.Getenv("SUBSONIC_PASSWORD"),
+	}
+}
+
+// requireAuth authenticates the request, writing a failure envelope and
+// returning false if it doesn't check out.
+func (rt *Router) requireAuth(ctx *gserv.Context) bool {
+	if rt.authenticate(ctx.Req) {
+		return true
+	}
+	writeError(ctx, ctx.Req, ErrorWrongCredentials, "Wrong username or password")
+	return false
+}
+
+// Ping handles GET /rest/ping.view
+func (rt *Router) Ping(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+	writeResponse(ctx, ctx.Req, Envelope{})
+	return nil
+}
+
+// GetMusicFolders handles GET /rest/getMusicFolders.view
+func (rt *Router) GetMusicFolders(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+	writeResponse(ctx, ctx.Req, Envelope{
+		MusicFolders: &MusicFolders{Folder: []MusicFolder{{ID: "0", Name: "Music"}}},
+	})
+	return nil
+}
+
+// GetIndexes handles GET /rest/getIndexes.view
+func (rt *Router) GetIndexes(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	artists, err := rt.library.ListArtists()
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorGeneric, "Failed to list artists")
+		return nil
+	}
+
+	byLetter := map[string][]Artist{}
+	var letters []string
+	for _, a := range artists {
+		letter := "#"
+		if a.Name != "" {
+			letter = strings.ToUpper(string([]rune(a.Name)[0]))
+		}
+		if _, ok := byLetter[letter]; !ok {
+			letters = append(letters, letter)
+		}
+		byLetter[letter] = append(byLetter[letter], Artist{ID: a.ID, Name: a.Name})
+	}
+	sort.Strings(letters)
+
+	indexes := make([]Index, 0, len(letters))
+	for _, letter := range letters {
+		indexes = append(indexes, Index{Name: letter, Artist: byLetter[letter]})
+	}
+
+	writeResponse(ctx, ctx.Req, Envelope{Indexes: &Indexes{Index: indexes}})
+	return nil
+}
+
+// GetAlbumList2 handles GET /rest/getAlbumList2.view
+func (rt *Router) GetAlbumList2(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	albums, err := rt.library.ListAlbums()
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorGeneric, "Failed to list albums")
+		return nil
+	}
+
+	size := queryInt(ctx.Req, "size", 500)
+	offset := queryInt(ctx.Req, "offset", 0)
+	if offset > len(albums) {
+		offset = len(albums)
+	}
+	end := offset + size
+	if end > len(albums) {
+		end = len(albums)
+	}
+
+	out := make([]Album, 0, end-offset)
+	for _, a := range albums[offset:end] {
+		out = append(out, toSubsonicAlbum(a, nil))
+	}
+
+	writeResponse(ctx, ctx.Req, Envelope{AlbumList2: &AlbumList2{Album: out}})
+	return nil
+}
+
+// GetAlbum handles GET /rest/getAlbum.view?id=
+func (rt *Router) GetAlbum(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	id := ctx.Req.URL.Query().Get("id")
+	album, err := rt.library.GetAlbum(id)
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorDataNotFound, "Album not found")
+		return nil
+	}
+	tracks, err := rt.library.ListTracksByAlbum(id)
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorGeneric, "Failed to list album tracks")
+		return nil
+	}
+
+	songs := make([]Song, 0, len(tracks))
+	for _, t := range tracks {
+		songs = append(songs, toSubsonicSong(t))
+	}
+
+	out := toSubsonicAlbum(album, songs)
+	writeResponse(ctx, ctx.Req, Envelope{Album: &out})
+	return nil
+}
+
+// GetSong handles GET /rest/getSong.view?id=
+func (rt *Router) GetSong(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	track, err := rt.library.GetTrackByID(ctx.Req.URL.Query().Get("id"))
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorDataNotFound, "Song not found")
+		return nil
+	}
+
+	song := toSubsonicSong(track)
+	writeResponse(ctx, ctx.Req, Envelope{Song: &song})
+	return nil
+}
+
+// Stream handles GET /rest/stream.view?id=
+func (rt *Router) Stream(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+	rt.serveFile(ctx, false)
+	return nil
+}
+
+// Download handles GET /rest/download.view?id=
+func (rt *Router) Download(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+	rt.serveFile(ctx, true)
+	return nil
+}
+
+func (rt *Router) serveFile(ctx *gserv.Context, download bool) {
+	track, err := rt.library.GetTrackByID(ctx.Req.URL.Query().Get("id"))
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorDataNotFound, "Song not found")
+		return
+	}
+	if download {
+		ctx.Header().Set("Content-Disposition", `attachment; filename="`+track.Title+"."+track.Format+`"`)
+	}
+	streaming.ServeFile(ctx, ctx.Req, track.Path, "audio/"+track.Format)
+}
+
+// GetCoverArt handles GET /rest/getCoverArt.view?id=
+func (rt *Router) GetCoverArt(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	size := queryInt(ctx.Req, "size", 0)
+	rc, err := rt.artwork.Get(ctx.Req.URL.Query().Get("id"), size)
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorDataNotFound, "Cover art not found")
+		return nil
+	}
+	defer rc.Close()
+
+	ctx.Header().Set("Content-Type", "image/jpeg")
+	ctx.WriteHeader(http.StatusOK)
+	io.Copy(ctx, rc)
+	return nil
+}
+
+// Search3 handles GET /rest/search3.view?query=
+func (rt *Router) Search3(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	query := ctx.Req.URL.Query().Get("query")
+	artists, albums, tracks, err := rt.library.Search3(query)
+	if err != nil {
+		writeError(ctx, ctx.Req, ErrorGeneric, "Search failed")
+		return nil
+	}
+
+	result := &SearchResult3{}
+	for _, a := range artists {
+		result.Artist = append(result.Artist, Artist{ID: a.ID, Name: a.Name})
+	}
+	for _, a := range albums {
+		result.Album = append(result.Album, toSubsonicAlbum(a, nil))
+	}
+	for _, t := range tracks {
+		result.Song = append(result.Song, toSubsonicSong(t))
+	}
+
+	writeResponse(ctx, ctx.Req, Envelope{SearchResult3: result})
+	return nil
+}
+
+// Scrobble handles GET /rest/scrobble.view?id=
+//
+// The library doesn't keep play history, so this just validates the
+// request and acknowledges it, which is enough for clients that scrobble
+// fire-and-forget.
+func (rt *Router) Scrobble(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+	if _, err := rt.library.GetTrackByID(ctx.Req.URL.Query().Get("id")); err != nil {
+		writeError(ctx, ctx.Req, ErrorDataNotFound, "Song not found")
+		return nil
+	}
+	writeResponse(ctx, ctx.Req, Envelope{})
+	return nil
+}
+
+// GetLyrics handles GET /rest/getLyrics.view?artist=&title=
+func (rt *Router) GetLyrics(ctx *gserv.Context) gserv.Response {
+	if !rt.requireAuth(ctx) {
+		return nil
+	}
+
+	q := ctx.Req.URL.Query()
+	artist, title := q.Get("artist"), q.Get("title")
+
+	lyrics, err := rt.agents.Lyrics(ctx.Req.Context(), artist, title, "", 0)
+	if err != nil {
+		writeResponse(ctx, ctx.Req, Envelope{Lyrics: &Lyrics{Artist: artist, Title: title}})
+		return nil
+	}
+
+	writeResponse(ctx, ctx.Req, Envelope{Lyrics: &Lyrics{Artist: artist, Title: title, Value: lyricsText(lyrics)}})
+	return nil
+}
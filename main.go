@@ -8,15 +8,30 @@ import (
 	"time"
 
 	"yhsrv/internal/handlers"
+	"yhsrv/internal/scanner"
+	"yhsrv/internal/subsonic"
 
 	"go.oneofone.dev/gserv"
 )
 
+const musicDir = "./music"
+
 func main() {
 	// Graceful shutdown setup
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
 	defer stop()
 
+	// Index the music library, then keep it in sync via fs notifications.
+	libScanner := scanner.New(handlers.Library(), musicDir)
+	if err := libScanner.ScanAll(ctx); err != nil {
+		log.Fatalf("initial library scan failed: %v", err)
+	}
+	go func() {
+		if err := libScanner.Watch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("library watch stopped: %v", err)
+		}
+	}()
+
 	// Set up gserv server
 	server := gserv.New(gserv.WriteTimeout(time.Second*30), gserv.ReadTimeout(time.Second*30))
 
@@ -25,6 +40,31 @@ func main() {
 	server.GET("/tracks", handlers.GetTracks)
 	server.GET("/track/{id}", handlers.GetTrack)
 	server.GET("/stream/{id}", handlers.StreamTrack)
+	server.GET("/artwork/{id}", handlers.GetArtwork)
+	server.GET("/artist/{id}/info", handlers.GetArtistInfo)
+	server.GET("/artist/{id}/similar", handlers.GetSimilarArtists)
+	server.GET("/track/{id}/lyrics", handlers.GetLyrics)
+
+	// Serve HLS playlists, transcoding and caching segments on demand -
+	// friendlier than raw Range streaming on mobile/lossy networks.
+	server.GET("/hls/{id}/master.m3u8", handlers.GetHLSMaster)
+	server.GET("/hls/{id}/{variant}/{file}", handlers.GetHLSResource)
+
+	// Serve the Subsonic/OpenSubsonic-compatible REST API for existing
+	// client apps (DSub, play:Sub, Symfonium, Feishin, ...)
+	subsonicAPI := subsonic.New(handlers.Library(), handlers.Artwork(), handlers.Agents())
+	server.GET("/rest/ping.view", subsonicAPI.Ping)
+	server.GET("/rest/getMusicFolders.view", subsonicAPI.GetMusicFolders)
+	server.GET("/rest/getIndexes.view", subsonicAPI.GetIndexes)
+	server.GET("/rest/getAlbumList2.view", subsonicAPI.GetAlbumList2)
+	server.GET("/rest/getAlbum.view", subsonicAPI.GetAlbum)
+	server.GET("/rest/getSong.view", subsonicAPI.GetSong)
+	server.GET("/rest/stream.view", subsonicAPI.Stream)
+	server.GET("/rest/download.view", subsonicAPI.Download)
+	server.GET("/rest/getCoverArt.view", subsonicAPI.GetCoverArt)
+	server.GET("/rest/search3.view", subsonicAPI.Search3)
+	server.GET("/rest/scrobble.view", subsonicAPI.Scrobble)
+	server.GET("/rest/getLyrics.view", subsonicAPI.GetLyrics)
 
 	// Start server
 	port := ":8080"